@@ -14,6 +14,12 @@ func TestAliasMap(t *testing.T) {
 	b := a.Copy()
 
 	require.Equal(t, a, b)
-	require.Equal(t, "b", a.Get("a"))
-	require.Equal(t, "e", a.Get("e"))
+
+	target, err := a.Get("a")
+	require.Nil(t, err)
+	require.Equal(t, "b", target)
+
+	target, err = a.Get("e")
+	require.Nil(t, err)
+	require.Equal(t, "e", target)
 }
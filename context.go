@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // Context can build items thanks to their definition contained in a ContextManager.
@@ -14,6 +15,18 @@ type Context struct {
 	parent         *Context
 	children       []*Context
 	items          map[string]interface{}
+	meta           map[string]itemMeta
+	globals        map[string]interface{}
+	globalFns      map[string]func() interface{}
+	logger         Logger
+}
+
+// itemMeta tracks the bookkeeping a GCPolicy needs to evict an item:
+// when it was last read, how big it is and how eager it is to be kept.
+type itemMeta struct {
+	lastUsed time.Time
+	size     uint64
+	priority int
 }
 
 // Scope returns the name of the context scope.
@@ -110,6 +123,7 @@ func (ctx *Context) subContext(scope string, subscopes []string) (*Context, erro
 		parent:         ctx,
 		children:       []*Context{},
 		items:          map[string]interface{}{},
+		meta:           map[string]itemMeta{},
 	}
 
 	ctx.children = append(ctx.children, child)
@@ -129,26 +143,39 @@ func (ctx *Context) subContext(scope string, subscopes []string) (*Context, erro
 func (ctx *Context) SafeGet(name string) (interface{}, error) {
 	manager := ctx.ContextManager()
 	if manager == nil {
+		ctx.Logger().Error("safe get failed", "name", name, "error", "context has been deleted")
 		return nil, errors.New("context has been deleted")
 	}
 
 	n, err := manager.ResolveName(name)
 	if err != nil {
+		ctx.Logger().Error("safe get failed", "name", name, "error", err.Error())
 		return nil, err
 	}
 
 	// name is registered, check if it matches an Instance in the ContextManager
-	if instance, ok := manager.instances[n]; ok {
+	if instance, ok := manager.instance(n); ok {
 		return instance, nil
 	}
 
 	// it's not an Instance, so it's a Maker
 	// try to find the Maker in the ContextManager
-	maker, ok := manager.makers[n]
+	maker, ok := manager.maker(n)
 	if !ok {
-		return nil, fmt.Errorf("could not find Maker for `%s` in the ContextManager", name)
+		err := fmt.Errorf("could not find Maker for `%s` in the ContextManager", name)
+		ctx.Logger().Error("safe get failed", "name", name, "error", err.Error())
+		return nil, err
 	}
 
+	// detect a Maker whose Make transitively calls back into SafeGet
+	// for the same name, instead of recursing or deadlocking forever
+	exitResolution, err := manager.enterResolution(n)
+	if err != nil {
+		ctx.Logger().Error("safe get failed", "name", name, "error", err.Error())
+		return nil, err
+	}
+	defer exitResolution()
+
 	// if the Maker scope doesn't math this Context scope
 	// try to make the item in a parent Context matching the Maker scope
 	if ctx.scope != maker.Scope {
@@ -163,6 +190,9 @@ func (ctx *Context) makeInThisContext(maker Maker) (interface{}, error) {
 	// try to reuse an already made item
 	ctx.m.Lock()
 	item, ok := ctx.items[maker.Name]
+	if ok {
+		ctx.touch(maker.Name)
+	}
 	ctx.m.Unlock()
 
 	if ok {
@@ -172,30 +202,69 @@ func (ctx *Context) makeInThisContext(maker Maker) (interface{}, error) {
 	// the item has not been made yet, so create it
 	item, err := ctx.makeItem(maker)
 	if err != nil {
+		ctx.Logger().Error("make failed", "makerName", maker.Name, "error", err.Error())
 		return nil, err
 	}
 
 	// ensure the Context is not closed before adding the item
 	ctx.m.Lock()
-	defer ctx.m.Unlock()
 
 	if ctx.contextManager == nil {
+		ctx.m.Unlock()
 		return nil, errors.New("context has been deleted")
 	}
 
 	ctx.items[maker.Name] = item
+	ctx.meta[maker.Name] = ctx.newItemMeta(maker, item)
+
+	ctx.m.Unlock()
+
+	ctx.Logger().Info("maker built item", "makerName", maker.Name)
 
 	return item, nil
 }
 
+// touch refreshes the last-used timestamp of a cached item.
+// The caller must hold ctx.m.
+func (ctx *Context) touch(name string) {
+	meta := ctx.meta[name]
+	meta.lastUsed = time.Now()
+	ctx.meta[name] = meta
+}
+
+// newItemMeta builds the bookkeeping entry for a freshly built item,
+// using the owning ContextManager's GCPolicy for this Context's scope.
+// The caller must hold ctx.m.
+func (ctx *Context) newItemMeta(maker Maker, item interface{}) itemMeta {
+	var size uint64
+	if maker.Size != nil {
+		size = maker.Size(item)
+	}
+
+	var priority int
+	if ctx.contextManager != nil {
+		if policy := ctx.contextManager.gcPolicy(ctx.scope); policy.Priority != nil {
+			priority = policy.Priority(maker.Name)
+		}
+	}
+
+	return itemMeta{
+		lastUsed: time.Now(),
+		size:     size,
+		priority: priority,
+	}
+}
+
 func (ctx *Context) makeInParent(maker Maker) (interface{}, error) {
 	parent := ctx.ParentWithScope(maker.Scope)
 	if parent == nil {
-		return nil, fmt.Errorf(
+		err := fmt.Errorf(
 			"Maker for `%s` requires `%s` scope which does not match this Context scope or any of its parents scope",
 			maker.Name,
 			maker.Scope,
 		)
+		ctx.Logger().Error("make in parent failed", "makerName", maker.Name, "error", err.Error())
+		return nil, err
 	}
 
 	return parent.makeInThisContext(maker)
@@ -209,7 +278,22 @@ func (ctx *Context) makeItem(maker Maker) (item interface{}, err error) {
 	}()
 
 	item, err = maker.Make(ctx)
-	return
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hook := range maker.PostBuild {
+		if hook == nil {
+			continue
+		}
+
+		if err = hook(item, ctx); err != nil {
+			ctx.close(maker, item)
+			return nil, fmt.Errorf("PostBuild hook for `%s` failed: %s", maker.Name, err)
+		}
+	}
+
+	return item, nil
 }
 
 // Get is similar to SafeGet but it does not return the error.
@@ -254,7 +338,7 @@ func (ctx *Context) Delete() {
 
 	for name, item := range ctx.items {
 		items[name] = item
-		if maker, ok := ctx.contextManager.makers[name]; ok {
+		if maker, ok := ctx.contextManager.maker(name); ok {
 			makers[name] = maker
 		}
 	}
@@ -264,6 +348,8 @@ func (ctx *Context) Delete() {
 
 	ctx.m.Unlock()
 
+	ctx.Logger().Info("context deleted", "itemCount", len(items))
+
 	// delete children
 	for _, child := range children {
 		child.Delete()
@@ -293,14 +379,26 @@ func (ctx *Context) Delete() {
 	ctx.parent = nil
 	ctx.children = nil
 	ctx.items = nil
+	ctx.meta = nil
 	ctx.m.Unlock()
 }
 
 func (ctx *Context) close(maker Maker, item interface{}) {
 	defer func() {
-		recover()
+		if r := recover(); r != nil {
+			ctx.Logger().Error("maker close panicked", "makerName", maker.Name, "panic", fmt.Sprint(r))
+		}
 	}()
 
+	for _, hook := range maker.PreClose {
+		if hook == nil {
+			continue
+		}
+
+		hook(item, ctx)
+	}
+
 	maker.Close(item)
-	return
+
+	ctx.Logger().Info("maker closed item", "makerName", maker.Name)
 }
@@ -0,0 +1,121 @@
+package di
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrCyclicDependency is returned by SafeGet when making an item requires,
+// transitively, making that same item again.
+type ErrCyclicDependency struct {
+	Chain []string
+}
+
+func (e *ErrCyclicDependency) Error() string {
+	return fmt.Sprintf("cyclic dependency: %s", strings.Join(e.Chain, " -> "))
+}
+
+// enterResolution pushes name on the resolution stack of the calling
+// goroutine and returns a func that pops it back off. It fails with an
+// ErrCyclicDependency if name is already on that stack, i.e. it is
+// already being made higher up the call chain. The goroutine id is
+// computed once and reused for the matching exitResolution, so a
+// SafeGet call only ever pays for it once even though it enters and
+// exits resolution around the same name.
+func (cm *ContextManager) enterResolution(name string) (func(), error) {
+	gid := goroutineID()
+
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	stack := cm.resolving[gid]
+
+	if stringSliceContains(stack, name) {
+		return nil, &ErrCyclicDependency{Chain: append(append([]string{}, stack...), name)}
+	}
+
+	cm.resolving[gid] = append(stack, name)
+
+	return func() { cm.exitResolution(gid, name) }, nil
+}
+
+// exitResolution pops name off the resolution stack identified by gid,
+// cleaning up the stack entirely once it is empty.
+func (cm *ContextManager) exitResolution(gid uint64, name string) {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	stack := cm.resolving[gid]
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == name {
+			stack = append(stack[:i], stack[i+1:]...)
+			break
+		}
+	}
+
+	if len(stack) == 0 {
+		delete(cm.resolving, gid)
+		return
+	}
+
+	cm.resolving[gid] = stack
+}
+
+// Validate statically walks every registered Maker's declared
+// Dependencies and reports an ErrCyclicDependency if it finds a cycle,
+// so that a misconfigured ContextManager fails at boot instead of on
+// the first request that happens to trigger the cycle.
+func (cm *ContextManager) Validate() error {
+	cm.m.Lock()
+	makers := make(map[string]Maker, len(cm.makers))
+	for name, maker := range cm.makers {
+		makers[name] = maker
+	}
+	aliases := cm.aliases.Copy()
+	cm.m.Unlock()
+
+	visited := map[string]int{} // 0 = unvisited, 1 = in progress, 2 = done
+
+	var walk func(name string, chain []string) error
+	walk = func(name string, chain []string) error {
+		switch visited[name] {
+		case 2:
+			return nil
+		case 1:
+			return &ErrCyclicDependency{Chain: append(chain, name)}
+		}
+
+		visited[name] = 1
+		chain = append(chain, name)
+
+		maker, ok := makers[name]
+		if ok {
+			for _, dep := range maker.Dependencies {
+				// resolve through the alias map first, exactly as SafeGet
+				// does via ResolveName, so a cycle hidden behind an alias
+				// is still caught here instead of at request time
+				resolved, err := aliases.Get(dep)
+				if err != nil {
+					return err
+				}
+
+				if err := walk(resolved, chain); err != nil {
+					return err
+				}
+			}
+		}
+
+		visited[name] = 2
+
+		return nil
+	}
+
+	for name := range makers {
+		if err := walk(name, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeGetDetectsCycle(t *testing.T) {
+	cm := NewContextManager("app")
+
+	require.Nil(t, cm.Set(Maker{
+		Scope: "app",
+		Name:  "a",
+		Make: func(ctx *Context) (interface{}, error) {
+			return ctx.SafeGet("b")
+		},
+		Close: func(interface{}) {},
+	}))
+	require.Nil(t, cm.Set(Maker{
+		Scope: "app",
+		Name:  "b",
+		Make: func(ctx *Context) (interface{}, error) {
+			return ctx.SafeGet("a")
+		},
+		Close: func(interface{}) {},
+	}))
+
+	_, err := cm.Root().SafeGet("a")
+	require.Error(t, err)
+
+	var cyclic *ErrCyclicDependency
+	require.ErrorAs(t, err, &cyclic)
+	require.Equal(t, []string{"a", "b", "a"}, cyclic.Chain)
+}
+
+func TestValidateDetectsCycle(t *testing.T) {
+	cm := NewContextManager("app")
+
+	require.Nil(t, cm.Set(Maker{
+		Scope:        "app",
+		Name:         "a",
+		Make:         func(ctx *Context) (interface{}, error) { return nil, nil },
+		Close:        func(interface{}) {},
+		Dependencies: []string{"b"},
+	}))
+	require.Nil(t, cm.Set(Maker{
+		Scope:        "app",
+		Name:         "b",
+		Make:         func(ctx *Context) (interface{}, error) { return nil, nil },
+		Close:        func(interface{}) {},
+		Dependencies: []string{"a"},
+	}))
+
+	err := cm.Validate()
+	require.Error(t, err)
+}
+
+func TestValidateDetectsCycleThroughAlias(t *testing.T) {
+	cm := NewContextManager("app")
+
+	require.Nil(t, cm.Set(Maker{
+		Scope:        "app",
+		Name:         "x",
+		Make:         func(ctx *Context) (interface{}, error) { return nil, nil },
+		Close:        func(interface{}) {},
+		Dependencies: []string{"aliasY"},
+	}))
+	require.Nil(t, cm.Set(Maker{
+		Scope:        "app",
+		Name:         "y",
+		Make:         func(ctx *Context) (interface{}, error) { return nil, nil },
+		Close:        func(interface{}) {},
+		Dependencies: []string{"x"},
+	}))
+	cm.Alias("aliasY", "y")
+
+	err := cm.Validate()
+	require.Error(t, err)
+
+	var cyclic *ErrCyclicDependency
+	require.ErrorAs(t, err, &cyclic)
+}
+
+func TestValidateAcceptsAcyclicGraph(t *testing.T) {
+	cm := NewContextManager("app")
+
+	require.Nil(t, cm.Set(Maker{
+		Scope:        "app",
+		Name:         "a",
+		Make:         func(ctx *Context) (interface{}, error) { return nil, nil },
+		Close:        func(interface{}) {},
+		Dependencies: []string{"b"},
+	}))
+	require.Nil(t, cm.Set(Maker{
+		Scope: "app",
+		Name:  "b",
+		Make:  func(ctx *Context) (interface{}, error) { return nil, nil },
+		Close: func(interface{}) {},
+	}))
+
+	require.Nil(t, cm.Validate())
+}
@@ -0,0 +1,173 @@
+package di
+
+import (
+	"context"
+	"sort"
+)
+
+// Definition describes a name registered in a ContextManager: which
+// scope builds it, what aliases point to it, and whether it has already
+// been built in the Context the Definition was read from, if any.
+type Definition struct {
+	Name    string
+	Scope   string
+	Aliases []string
+	Built   bool
+}
+
+// Iterator streams a Definition for every Maker registered in cm,
+// in chunks of chunkSize, optionally narrowed down by filter. The set of
+// names is snapshotted once to give chunks a stable order, and each
+// chunk re-reads the makers for those names under lock, so a Maker that
+// another goroutine re-registers under a snapshotted name is read as it
+// stands when its chunk is built, without mutating a Definition already
+// handed to the caller. Names registered after the snapshot are not
+// streamed. If goCtx is done
+// before the channel is drained, for example because the caller broke
+// out of the range early, the producing goroutine stops instead of
+// blocking forever on a send nobody will receive.
+func (cm *ContextManager) Iterator(goCtx context.Context, chunkSize int, filter func(Definition) bool) <-chan Definition {
+	if chunkSize <= 0 {
+		chunkSize = 1
+	}
+
+	out := make(chan Definition)
+
+	go func() {
+		defer close(out)
+
+		cm.m.Lock()
+		names := make([]string, 0, len(cm.makers))
+		for name := range cm.makers {
+			names = append(names, name)
+		}
+		cm.m.Unlock()
+
+		sort.Strings(names)
+
+		for start := 0; start < len(names); start += chunkSize {
+			end := start + chunkSize
+			if end > len(names) {
+				end = len(names)
+			}
+
+			cm.m.Lock()
+			chunk := make([]Definition, 0, end-start)
+			for _, name := range names[start:end] {
+				maker, ok := cm.makers[name]
+				if !ok {
+					continue
+				}
+
+				chunk = append(chunk, Definition{
+					Name:    name,
+					Scope:   maker.Scope,
+					Aliases: cm.aliasesFor(name),
+				})
+			}
+			cm.m.Unlock()
+
+			for _, def := range chunk {
+				if filter != nil && !filter(def) {
+					continue
+				}
+
+				select {
+				case out <- def:
+				case <-goCtx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// aliasesFor returns the registered names that directly alias target.
+// The caller must hold cm.m.
+func (cm *ContextManager) aliasesFor(target string) []string {
+	var aliases []string
+
+	for name, t := range cm.aliases {
+		if t == target {
+			aliases = append(aliases, name)
+		}
+	}
+
+	sort.Strings(aliases)
+
+	return aliases
+}
+
+// Iterator streams a Definition for every item already built in ctx, in
+// chunks of chunkSize, optionally narrowed down by filter. As with
+// ContextManager.Iterator, the set of names is snapshotted once for a
+// stable order, each chunk re-reads ctx.items under lock, and the
+// producing goroutine stops as soon as goCtx is done instead of blocking
+// forever if the caller stops draining the channel early.
+func (ctx *Context) Iterator(goCtx context.Context, chunkSize int, filter func(Definition) bool) <-chan Definition {
+	if chunkSize <= 0 {
+		chunkSize = 1
+	}
+
+	out := make(chan Definition)
+
+	go func() {
+		defer close(out)
+
+		ctx.m.Lock()
+		manager := ctx.contextManager
+		names := make([]string, 0, len(ctx.items))
+		for name := range ctx.items {
+			names = append(names, name)
+		}
+		ctx.m.Unlock()
+
+		sort.Strings(names)
+
+		for start := 0; start < len(names); start += chunkSize {
+			end := start + chunkSize
+			if end > len(names) {
+				end = len(names)
+			}
+
+			ctx.m.Lock()
+			chunk := make([]Definition, 0, end-start)
+			for _, name := range names[start:end] {
+				if _, ok := ctx.items[name]; !ok {
+					continue
+				}
+
+				def := Definition{
+					Name:  name,
+					Scope: ctx.scope,
+					Built: true,
+				}
+
+				if manager != nil {
+					manager.m.Lock()
+					def.Aliases = manager.aliasesFor(name)
+					manager.m.Unlock()
+				}
+
+				chunk = append(chunk, def)
+			}
+			ctx.m.Unlock()
+
+			for _, def := range chunk {
+				if filter != nil && !filter(def) {
+					continue
+				}
+
+				select {
+				case out <- def:
+				case <-goCtx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,70 @@
+package di
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextManagerIterator(t *testing.T) {
+	cm := NewContextManager("app")
+
+	require.Nil(t, cm.Set(Maker{Scope: "app", Name: "a", Make: func(ctx *Context) (interface{}, error) { return nil, nil }, Close: func(interface{}) {}}))
+	require.Nil(t, cm.Set(Maker{Scope: "app", Name: "b", Make: func(ctx *Context) (interface{}, error) { return nil, nil }, Close: func(interface{}) {}}))
+	cm.Alias("a-alias", "a")
+
+	var defs []Definition
+	for def := range cm.Iterator(context.Background(), 1, nil) {
+		defs = append(defs, def)
+	}
+
+	require.Len(t, defs, 2)
+	require.Equal(t, "a", defs[0].Name)
+	require.Equal(t, []string{"a-alias"}, defs[0].Aliases)
+	require.False(t, defs[0].Built)
+}
+
+func TestContextIterator(t *testing.T) {
+	cm := NewContextManager("app")
+
+	require.Nil(t, cm.Set(Maker{Scope: "app", Name: "a", Make: func(ctx *Context) (interface{}, error) { return "v", nil }, Close: func(interface{}) {}}))
+	require.Nil(t, cm.Set(Maker{Scope: "app", Name: "b", Make: func(ctx *Context) (interface{}, error) { return "v", nil }, Close: func(interface{}) {}}))
+
+	root := cm.Root()
+	_, err := root.SafeGet("a")
+	require.Nil(t, err)
+
+	var defs []Definition
+	for def := range root.Iterator(context.Background(), 10, nil) {
+		defs = append(defs, def)
+	}
+
+	require.Len(t, defs, 1)
+	require.Equal(t, "a", defs[0].Name)
+	require.True(t, defs[0].Built)
+}
+
+func TestContextManagerIteratorStopsOnCancel(t *testing.T) {
+	cm := NewContextManager("app")
+
+	for _, name := range []string{"a", "b", "c"} {
+		require.Nil(t, cm.Set(Maker{Scope: "app", Name: name, Make: func(ctx *Context) (interface{}, error) { return nil, nil }, Close: func(interface{}) {}}))
+	}
+
+	before := runtime.NumGoroutine()
+
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for range cm.Iterator(goCtx, 1, nil) {
+		cancel()
+		break
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	require.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
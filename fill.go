@@ -0,0 +1,27 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// fill copies src into dst, where dst must be a pointer to a value
+// assignable from src.
+func fill(src, dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("fill: dst must be a non-nil pointer, got `%T`", dst)
+	}
+
+	dstElem := dstValue.Elem()
+	srcValue := reflect.ValueOf(src)
+
+	if !srcValue.IsValid() || !srcValue.Type().AssignableTo(dstElem.Type()) {
+		return fmt.Errorf("fill: could not assign `%T` to `%T`", src, dst)
+	}
+
+	dstElem.Set(srcValue)
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// GCPolicy bounds how many items, and for how long, a scope is allowed
+// to keep around. It is configured per scope on the ContextManager with
+// ContextManager.SetGCPolicy and enforced by Context.GC and Context.Prune.
+type GCPolicy struct {
+	// MaxSize is the total size, in the unit returned by Maker.Size, a
+	// Context is allowed to hold before the least useful items are
+	// evicted. A zero value means no size limit.
+	MaxSize uint64
+
+	// MaxKeepDuration is how long an item can go unused before it
+	// becomes eligible for eviction. A zero value means no idle limit.
+	MaxKeepDuration time.Duration
+
+	// Priority optionally ranks items by name: lower priority items are
+	// evicted first when MaxSize is exceeded. A nil Priority treats
+	// every item the same and falls back to pure LRU.
+	Priority func(name string) int
+}
+
+// Prune walks the items built in this Context and evicts the ones that
+// are idle for longer than the scope's GCPolicy.MaxKeepDuration, or that
+// must go to bring the Context back under GCPolicy.MaxSize, starting
+// with the lowest priority, least recently used items. Evicted items
+// have their PreClose hooks and Maker.Close called, exactly as Delete
+// does. It returns the size freed per evicted item name.
+func (c *Context) Prune(goCtx context.Context) (map[string]int64, error) {
+	manager := c.ContextManager()
+	if manager == nil {
+		return nil, errors.New("context has been deleted")
+	}
+
+	policy := manager.gcPolicy(c.scope)
+
+	c.m.Lock()
+
+	type candidate struct {
+		name string
+		meta itemMeta
+	}
+
+	candidates := make([]candidate, 0, len(c.items))
+	var total uint64
+
+	for name, meta := range c.meta {
+		candidates = append(candidates, candidate{name: name, meta: meta})
+		total += meta.size
+	}
+
+	now := time.Now()
+	toEvict := map[string]struct{}{}
+
+	if policy.MaxKeepDuration > 0 {
+		for _, cand := range candidates {
+			if now.Sub(cand.meta.lastUsed) >= policy.MaxKeepDuration {
+				toEvict[cand.name] = struct{}{}
+				total -= cand.meta.size
+			}
+		}
+	}
+
+	if policy.MaxSize > 0 && total > policy.MaxSize {
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].meta.priority != candidates[j].meta.priority {
+				return candidates[i].meta.priority < candidates[j].meta.priority
+			}
+			return candidates[i].meta.lastUsed.Before(candidates[j].meta.lastUsed)
+		})
+
+		for _, cand := range candidates {
+			if total <= policy.MaxSize {
+				break
+			}
+			if _, ok := toEvict[cand.name]; ok {
+				continue
+			}
+			toEvict[cand.name] = struct{}{}
+			total -= cand.meta.size
+		}
+	}
+
+	items := make(map[string]interface{}, len(toEvict))
+	makers := make(map[string]Maker, len(toEvict))
+	freed := make(map[string]int64, len(toEvict))
+
+	var err error
+
+	for name := range toEvict {
+		if err = goCtx.Err(); err != nil {
+			break
+		}
+
+		items[name] = c.items[name]
+		freed[name] = int64(c.meta[name].size)
+
+		if maker, ok := manager.maker(name); ok {
+			makers[name] = maker
+		}
+
+		delete(c.items, name)
+		delete(c.meta, name)
+	}
+
+	c.m.Unlock()
+
+	for name, item := range items {
+		if maker, ok := makers[name]; ok {
+			c.close(maker, item)
+		}
+	}
+
+	return freed, err
+}
+
+// GC prunes this Context according to its scope's GCPolicy and discards
+// the per-item accounting Prune would otherwise return. Use Prune
+// directly if you need to know what was evicted.
+func (c *Context) GC(goCtx context.Context) error {
+	_, err := c.Prune(goCtx)
+	return err
+}
@@ -0,0 +1,122 @@
+package di
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextGC(t *testing.T) {
+	cm := NewContextManager("app")
+
+	err := cm.SetGCPolicy("app", GCPolicy{MaxKeepDuration: time.Millisecond})
+	require.Nil(t, err)
+
+	var closed bool
+
+	err = cm.Set(Maker{
+		Scope: "app",
+		Name:  "cache",
+		Make: func(ctx *Context) (interface{}, error) {
+			return "value", nil
+		},
+		Close: func(interface{}) {
+			closed = true
+		},
+	})
+	require.Nil(t, err)
+
+	root := cm.Root()
+
+	_, err = root.SafeGet("cache")
+	require.Nil(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	freed, err := root.Prune(context.Background())
+	require.Nil(t, err)
+	require.Contains(t, freed, "cache")
+	require.True(t, closed)
+
+	_, ok := root.items["cache"]
+	require.False(t, ok)
+}
+
+func TestContextPruneIdleReducesSizeTotal(t *testing.T) {
+	cm := NewContextManager("app")
+
+	err := cm.SetGCPolicy("app", GCPolicy{MaxKeepDuration: time.Millisecond, MaxSize: 15})
+	require.Nil(t, err)
+
+	sizes := map[string]uint64{"idle": 10, "fresh-1": 5, "fresh-2": 5}
+
+	for name, size := range sizes {
+		size := size
+		err = cm.Set(Maker{
+			Scope: "app",
+			Name:  name,
+			Make: func(ctx *Context) (interface{}, error) {
+				return "value", nil
+			},
+			Close: func(interface{}) {},
+			Size: func(interface{}) uint64 {
+				return size
+			},
+		})
+		require.Nil(t, err)
+	}
+
+	root := cm.Root()
+
+	_, err = root.SafeGet("idle")
+	require.Nil(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	_, err = root.SafeGet("fresh-1")
+	require.Nil(t, err)
+	_, err = root.SafeGet("fresh-2")
+	require.Nil(t, err)
+
+	freed, err := root.Prune(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, map[string]int64{"idle": 10}, freed)
+
+	_, ok := root.items["fresh-1"]
+	require.True(t, ok)
+	_, ok = root.items["fresh-2"]
+	require.True(t, ok)
+}
+
+func TestContextGCMaxSize(t *testing.T) {
+	cm := NewContextManager("app")
+
+	err := cm.SetGCPolicy("app", GCPolicy{MaxSize: 1})
+	require.Nil(t, err)
+
+	err = cm.Set(Maker{
+		Scope: "app",
+		Name:  "big",
+		Make: func(ctx *Context) (interface{}, error) {
+			return "value", nil
+		},
+		Close: func(interface{}) {},
+		Size: func(interface{}) uint64 {
+			return 10
+		},
+	})
+	require.Nil(t, err)
+
+	root := cm.Root()
+
+	_, err = root.SafeGet("big")
+	require.Nil(t, err)
+
+	err = root.GC(context.Background())
+	require.Nil(t, err)
+
+	_, ok := root.items["big"]
+	require.False(t, ok)
+}
@@ -0,0 +1,88 @@
+package di
+
+import "context"
+
+// diContextKey is the unexported key used to carry a *Context inside a
+// context.Context.
+type diContextKey struct{}
+
+// WithContext returns a copy of goCtx carrying ctx, so it can be handed
+// to another goroutine and read back there with FromContext. Unlike
+// inferring the calling goroutine's identity, this rides on the
+// standard context.Context propagation contract: it only ever reaches
+// the exact call chain goCtx was explicitly passed down, so concurrent
+// requests never share or clobber each other's Context.
+func WithContext(goCtx context.Context, ctx *Context) context.Context {
+	return context.WithValue(goCtx, diContextKey{}, ctx)
+}
+
+// FromContext returns the Context attached to goCtx with WithContext,
+// or nil if none was attached.
+func FromContext(goCtx context.Context) *Context {
+	ctx, _ := goCtx.Value(diContextKey{}).(*Context)
+
+	return ctx
+}
+
+// Go runs fn in a new goroutine with goCtx, typically built with
+// WithContext, so fn and anything it calls can read the Context back
+// with FromContext without it being threaded through as a second
+// parameter.
+func (cm *ContextManager) Go(goCtx context.Context, fn func(context.Context)) {
+	go fn(goCtx)
+}
+
+// PutGlobal stores value under key as contextual state available to
+// this Context and every one of its descendants, for things like trace
+// IDs, tenant IDs or deadlines that don't warrant a full Maker. It is
+// read back with Value.
+func (ctx *Context) PutGlobal(key string, value interface{}) {
+	ctx.m.Lock()
+	defer ctx.m.Unlock()
+
+	if ctx.globals == nil {
+		ctx.globals = map[string]interface{}{}
+	}
+
+	ctx.globals[key] = value
+}
+
+// PutGlobalDynamic is similar to PutGlobal, but valueFn is called every
+// time the key is read with Value instead of storing a fixed value.
+func (ctx *Context) PutGlobalDynamic(key string, valueFn func() interface{}) {
+	ctx.m.Lock()
+	defer ctx.m.Unlock()
+
+	if ctx.globalFns == nil {
+		ctx.globalFns = map[string]func() interface{}{}
+	}
+
+	ctx.globalFns[key] = valueFn
+}
+
+// Value returns the contextual state stored under key with PutGlobal or
+// PutGlobalDynamic, looking it up in this Context and, if not found,
+// in its parents. It returns nil if key was never put.
+func (ctx *Context) Value(key string) interface{} {
+	ctx.m.Lock()
+
+	if value, ok := ctx.globals[key]; ok {
+		ctx.m.Unlock()
+		return value
+	}
+
+	if valueFn, ok := ctx.globalFns[key]; ok {
+		ctx.m.Unlock()
+		return valueFn()
+	}
+
+	parent := ctx.parent
+
+	ctx.m.Unlock()
+
+	if parent != nil {
+		return parent.Value(key)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package di
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerGoPropagatesContext(t *testing.T) {
+	cm := NewContextManager("app")
+
+	ctx := cm.Root()
+	ctx.PutGlobal("requestID", "req-1")
+
+	goCtx := WithContext(context.Background(), ctx)
+
+	var (
+		wg       sync.WaitGroup
+		gotCtx   *Context
+		gotValue interface{}
+	)
+
+	wg.Add(1)
+	cm.Go(goCtx, func(goCtx context.Context) {
+		defer wg.Done()
+		gotCtx = FromContext(goCtx)
+		if gotCtx != nil {
+			gotValue = gotCtx.Value("requestID")
+		}
+	})
+	wg.Wait()
+
+	require.Same(t, ctx, gotCtx)
+	require.Equal(t, "req-1", gotValue)
+}
+
+func TestFromContextIsolatesConcurrentCallers(t *testing.T) {
+	cm := NewContextManager("app", "request")
+
+	root := cm.Root()
+
+	var wg sync.WaitGroup
+	got := make([]*Context, 10)
+
+	for i := 0; i < 10; i++ {
+		req, err := root.SubContext("request")
+		require.Nil(t, err)
+
+		goCtx := WithContext(context.Background(), req)
+
+		wg.Add(1)
+		i, req := i, req
+		cm.Go(goCtx, func(goCtx context.Context) {
+			defer wg.Done()
+			got[i] = FromContext(goCtx)
+			require.Same(t, req, got[i])
+		})
+	}
+	wg.Wait()
+
+	seen := map[*Context]bool{}
+	for _, ctx := range got {
+		require.False(t, seen[ctx], "each concurrent caller must get its own Context")
+		seen[ctx] = true
+	}
+}
+
+func TestFromContextReturnsNilWhenNotAttached(t *testing.T) {
+	require.Nil(t, FromContext(context.Background()))
+}
+
+func TestContextValueDynamicAndInherited(t *testing.T) {
+	cm := NewContextManager("app", "request")
+
+	root := cm.Root()
+	root.PutGlobal("tenant", "acme")
+
+	calls := 0
+	root.PutGlobalDynamic("now", func() interface{} {
+		calls++
+		return calls
+	})
+
+	child, err := root.SubContext("request")
+	require.Nil(t, err)
+
+	require.Equal(t, "acme", child.Value("tenant"))
+	require.Equal(t, 1, root.Value("now"))
+	require.Equal(t, 2, root.Value("now"))
+	require.Nil(t, child.Value("missing"))
+}
@@ -0,0 +1,83 @@
+package di
+
+// Logger is the structured logging interface Context uses to emit
+// lifecycle events. Implementations typically wrap zap, zerolog or
+// slog. WithValues returns a Logger with kv appended to every future
+// log line, which Context uses to carry scope and contextID down to
+// every descendant without callers wiring it themselves.
+type Logger interface {
+	WithValues(kv ...interface{}) Logger
+	Info(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+}
+
+// noopLogger is the default Logger used until ContextManager.SetLogger
+// is called.
+type noopLogger struct{}
+
+func (noopLogger) WithValues(kv ...interface{}) Logger { return noopLogger{} }
+func (noopLogger) Info(msg string, kv ...interface{})  {}
+func (noopLogger) Error(msg string, kv ...interface{}) {}
+
+// SetLogger configures the Logger sink used by every Context created by
+// cm. It is meant to be called once, right after the ContextManager is
+// created, before any Context starts logging lazily through Logger().
+func (cm *ContextManager) SetLogger(logger Logger) {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	cm.loggerSink = logger
+}
+
+// logger returns the configured Logger sink, or a no-op Logger if
+// SetLogger was never called.
+func (cm *ContextManager) logger() Logger {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	if cm.loggerSink == nil {
+		return noopLogger{}
+	}
+
+	return cm.loggerSink
+}
+
+// Logger returns the Logger for this Context, deriving it from its
+// parent (or from the ContextManager sink for a root Context) the first
+// time it is requested, and appending this Context's scope and a
+// generated contextID to its structured fields. The result is cached so
+// every caller, and every descendant, sees the same contextID.
+func (ctx *Context) Logger() Logger {
+	ctx.m.Lock()
+	if ctx.logger != nil {
+		logger := ctx.logger
+		ctx.m.Unlock()
+		return logger
+	}
+
+	parent := ctx.parent
+	manager := ctx.contextManager
+	scope := ctx.scope
+	ctx.m.Unlock()
+
+	var base Logger
+	switch {
+	case parent != nil:
+		base = parent.Logger()
+	case manager != nil:
+		base = manager.logger()
+	default:
+		base = noopLogger{}
+	}
+
+	derived := base.WithValues("scope", scope, "contextID", newContextID())
+
+	ctx.m.Lock()
+	if ctx.logger == nil {
+		ctx.logger = derived
+	}
+	logger := ctx.logger
+	ctx.m.Unlock()
+
+	return logger
+}
@@ -0,0 +1,74 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	fields []interface{}
+	infos  []string
+	errors []string
+}
+
+func (l *recordingLogger) WithValues(kv ...interface{}) Logger {
+	return &recordingLogger{
+		fields: append(append([]interface{}{}, l.fields...), kv...),
+		infos:  l.infos,
+		errors: l.errors,
+	}
+}
+
+func (l *recordingLogger) Info(msg string, kv ...interface{}) {
+	l.infos = append(l.infos, msg)
+}
+
+func (l *recordingLogger) Error(msg string, kv ...interface{}) {
+	l.errors = append(l.errors, msg)
+}
+
+func TestContextLoggerInheritsScopeChain(t *testing.T) {
+	cm := NewContextManager("app", "request")
+
+	sink := &recordingLogger{}
+	cm.SetLogger(sink)
+
+	root := cm.Root()
+	rootLogger := root.Logger().(*recordingLogger)
+	require.Contains(t, rootLogger.fields, "app")
+
+	child, err := root.SubContext("request")
+	require.Nil(t, err)
+
+	childLogger := child.Logger().(*recordingLogger)
+	require.Contains(t, childLogger.fields, "app")
+	require.Contains(t, childLogger.fields, "request")
+
+	require.Same(t, child.Logger(), child.Logger())
+}
+
+func TestContextLoggerEmitsMakeAndCloseEvents(t *testing.T) {
+	cm := NewContextManager("app")
+
+	sink := &recordingLogger{}
+	cm.SetLogger(sink)
+
+	require.Nil(t, cm.Set(Maker{
+		Scope: "app",
+		Name:  "a",
+		Make:  func(ctx *Context) (interface{}, error) { return "v", nil },
+		Close: func(interface{}) {},
+	}))
+
+	root := cm.Root()
+
+	_, err := root.SafeGet("a")
+	require.Nil(t, err)
+
+	root.Delete()
+
+	logger := root.Logger().(*recordingLogger)
+	require.Contains(t, logger.infos, "maker built item")
+	require.Contains(t, logger.infos, "maker closed item")
+}
@@ -0,0 +1,35 @@
+package di
+
+// PostBuildHook is called right after a Maker has built an item,
+// before the item is handed back to the caller or cached in the Context.
+// It can be used to observe or mutate the item, for example to wire in
+// validation, metrics tagging or tracing spans without touching Make.
+type PostBuildHook func(item interface{}, ctx *Context) error
+
+// PreCloseHook is called right before a Maker closes an item.
+// It can be used to release secondary resources tied to the item
+// (health-checks, tracing spans, ...) before it is disposed of.
+type PreCloseHook func(item interface{}, ctx *Context) error
+
+// Maker knows how to build and close an item for a given scope.
+type Maker struct {
+	Scope string
+	Name  string
+	Make  func(ctx *Context) (interface{}, error)
+	Close func(interface{})
+
+	// PostBuild hooks run in order right after Make succeeds.
+	PostBuild []PostBuildHook
+
+	// PreClose hooks run in order right before Close is called.
+	PreClose []PreCloseHook
+
+	// Size optionally returns a size hint for a built item, used by the
+	// scope's GCPolicy to bound memory usage. A nil Size counts as 0.
+	Size func(item interface{}) uint64
+
+	// Dependencies optionally declares the names this Maker's Make is
+	// expected to SafeGet, so ContextManager.Validate can catch cycles
+	// statically instead of waiting for a request to trigger one.
+	Dependencies []string
+}
@@ -0,0 +1,138 @@
+package di
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ContextManager holds the Maker definitions and the named instances
+// shared across a tree of scopes, and is used to create the root Context.
+type ContextManager struct {
+	m          sync.Mutex
+	scopes     []string
+	aliases    AliasMap
+	makers     map[string]Maker
+	instances  map[string]interface{}
+	gcPolicies map[string]GCPolicy
+	resolving  map[uint64][]string
+	loggerSink Logger
+	root       *Context
+}
+
+// NewContextManager creates a ContextManager handling the given scopes.
+// Scopes must be declared from the most generic to the most specific,
+// for example: NewContextManager("app", "request").
+func NewContextManager(scopes ...string) *ContextManager {
+	cm := &ContextManager{
+		scopes:     scopes,
+		aliases:    AliasMap{},
+		makers:     map[string]Maker{},
+		instances:  map[string]interface{}{},
+		gcPolicies: map[string]GCPolicy{},
+		resolving:  map[uint64][]string{},
+	}
+
+	cm.root = &Context{
+		scope:          scopes[0],
+		contextManager: cm,
+		children:       []*Context{},
+		items:          map[string]interface{}{},
+		meta:           map[string]itemMeta{},
+	}
+
+	return cm
+}
+
+// Root returns the root Context, in the most generic scope.
+func (cm *ContextManager) Root() *Context {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	return cm.root
+}
+
+// Set registers a Maker. It fails if the Maker scope is not one of
+// the scopes declared on the ContextManager.
+func (cm *ContextManager) Set(maker Maker) error {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	if !stringSliceContains(cm.scopes, maker.Scope) {
+		return fmt.Errorf("could not register `%s`, `%s` is not a known scope", maker.Name, maker.Scope)
+	}
+
+	cm.makers[maker.Name] = maker
+
+	return nil
+}
+
+// SetInstance registers an already built instance under the given name.
+func (cm *ContextManager) SetInstance(name string, instance interface{}) {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	cm.instances[name] = instance
+}
+
+// Alias registers name as an alias targeting another registered name.
+func (cm *ContextManager) Alias(name, target string) {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	cm.aliases[name] = target
+}
+
+// ResolveName follows the aliases to find the name actually
+// registered in the ContextManager.
+func (cm *ContextManager) ResolveName(name string) (string, error) {
+	cm.m.Lock()
+	aliases := cm.aliases
+	cm.m.Unlock()
+
+	return aliases.Get(name)
+}
+
+// SetGCPolicy configures the GCPolicy applied by Context.GC and
+// Context.Prune to items built in the given scope. It fails if the
+// scope is not one of the scopes declared on the ContextManager.
+func (cm *ContextManager) SetGCPolicy(scope string, policy GCPolicy) error {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	if !stringSliceContains(cm.scopes, scope) {
+		return fmt.Errorf("could not set GCPolicy, `%s` is not a known scope", scope)
+	}
+
+	cm.gcPolicies[scope] = policy
+
+	return nil
+}
+
+// gcPolicy returns the GCPolicy configured for the given scope.
+func (cm *ContextManager) gcPolicy(scope string) GCPolicy {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	return cm.gcPolicies[scope]
+}
+
+// instance returns the instance registered under name, if any.
+func (cm *ContextManager) instance(name string) (interface{}, bool) {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	instance, ok := cm.instances[name]
+
+	return instance, ok
+}
+
+// maker returns the Maker registered under name, if any. Callers must
+// not already hold cm.m.
+func (cm *ContextManager) maker(name string) (Maker, bool) {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	maker, ok := cm.makers[name]
+
+	return maker, ok
+}
@@ -0,0 +1,43 @@
+package di
+
+// ProvideOptions configures the Maker registered by Provide beyond the
+// constructor itself, mirroring the fields Set callers get on a plain
+// Maker: how to dispose of the built value and any PostBuild/PreClose
+// hooks or Size hint to wire in around it.
+type ProvideOptions struct {
+	Close     func(interface{})
+	PostBuild []PostBuildHook
+	PreClose  []PreCloseHook
+	Size      func(interface{}) uint64
+}
+
+// Provide registers a typed Maker in cm for the given scope and name.
+// ctor builds the value directly, so callers no longer need to go through
+// the reflect-based Fill to get a strongly typed instance out of a
+// Context. opts is optional; when given, its Close/PostBuild/PreClose/Size
+// are wired into the underlying Maker exactly as they would be on a
+// Maker registered with Set, so a typed provider can still run cleanup
+// and hooks instead of leaking whatever ctor opened.
+func Provide[T any](cm *ContextManager, scope, name string, ctor func(*Context) (T, error), opts ...ProvideOptions) error {
+	var opt ProvideOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	closeFn := opt.Close
+	if closeFn == nil {
+		closeFn = func(interface{}) {}
+	}
+
+	return cm.Set(Maker{
+		Scope: scope,
+		Name:  name,
+		Make: func(ctx *Context) (interface{}, error) {
+			return ctor(ctx)
+		},
+		Close:     closeFn,
+		PostBuild: opt.PostBuild,
+		PreClose:  opt.PreClose,
+		Size:      opt.Size,
+	})
+}
@@ -0,0 +1,161 @@
+package di
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakerHooks(t *testing.T) {
+	var built, closed bool
+
+	cm := NewContextManager("app")
+
+	err := cm.Set(Maker{
+		Scope: "app",
+		Name:  "greeting",
+		Make: func(ctx *Context) (interface{}, error) {
+			return "hello", nil
+		},
+		Close: func(interface{}) {
+			closed = true
+		},
+		PostBuild: []PostBuildHook{
+			func(item interface{}, ctx *Context) error {
+				built = true
+				return nil
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	root := cm.Root()
+
+	item, err := root.SafeGet("greeting")
+	require.Nil(t, err)
+	require.Equal(t, "hello", item)
+	require.True(t, built)
+
+	root.Delete()
+	require.True(t, closed)
+}
+
+func TestMakerClosesItemWhenPostBuildFails(t *testing.T) {
+	var closed bool
+
+	cm := NewContextManager("app")
+
+	err := cm.Set(Maker{
+		Scope: "app",
+		Name:  "greeting",
+		Make: func(ctx *Context) (interface{}, error) {
+			return "hello", nil
+		},
+		Close: func(interface{}) {
+			closed = true
+		},
+		PostBuild: []PostBuildHook{
+			func(item interface{}, ctx *Context) error {
+				return errors.New("boom")
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	root := cm.Root()
+
+	_, err = root.SafeGet("greeting")
+	require.NotNil(t, err)
+	require.True(t, closed)
+
+	_, ok := root.items["greeting"]
+	require.False(t, ok)
+}
+
+func TestConcurrentSetAndSafeGet(t *testing.T) {
+	cm := NewContextManager("app")
+
+	require.Nil(t, cm.Set(Maker{
+		Scope: "app",
+		Name:  "existing",
+		Make: func(ctx *Context) (interface{}, error) {
+			return "value", nil
+		},
+		Close: func(interface{}) {},
+	}))
+
+	root := cm.Root()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			_, _ = root.SafeGet("existing")
+		}()
+
+		go func() {
+			defer wg.Done()
+			name := "new"
+			_ = cm.Set(Maker{
+				Scope: "app",
+				Name:  name,
+				Make: func(ctx *Context) (interface{}, error) {
+					return i, nil
+				},
+				Close: func(interface{}) {},
+			})
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestProvide(t *testing.T) {
+	cm := NewContextManager("app")
+
+	err := Provide(cm, "app", "answer", func(ctx *Context) (int, error) {
+		return 42, nil
+	})
+	require.Nil(t, err)
+
+	item, err := cm.Root().SafeGet("answer")
+	require.Nil(t, err)
+	require.Equal(t, 42, item)
+}
+
+func TestProvideWithOptionsWiresHooksAndClose(t *testing.T) {
+	cm := NewContextManager("app")
+
+	var built, closed bool
+
+	err := Provide(cm, "app", "answer", func(ctx *Context) (int, error) {
+		return 42, nil
+	}, ProvideOptions{
+		Close: func(interface{}) {
+			closed = true
+		},
+		PostBuild: []PostBuildHook{
+			func(item interface{}, ctx *Context) error {
+				built = true
+				return nil
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	root := cm.Root()
+
+	item, err := root.SafeGet("answer")
+	require.Nil(t, err)
+	require.Equal(t, 42, item)
+	require.True(t, built)
+
+	root.Delete()
+	require.True(t, closed)
+}
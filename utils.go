@@ -0,0 +1,56 @@
+package di
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// stringSliceContains returns true if s contains v.
+func stringSliceContains(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// goroutineID returns the id of the calling goroutine, as printed in its
+// stack trace. It has no supported API in the standard library. It is
+// used solely to key the resolution stack SafeGet needs to detect a
+// Maker that transitively calls back into itself: since that recursion
+// happens through arbitrary caller code we don't control (Maker.Make),
+// there is no exported parameter to thread the stack through instead.
+// Cross-goroutine Context propagation (ContextManager.Go) does not use
+// this: it rides on context.Context via WithContext/FromContext, so a
+// caller who forgets to clean up never hands a stale Context to an
+// unrelated future goroutine the way a goroutine-id lookup could.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	field := strings.Fields(strings.TrimPrefix(string(buf), "goroutine "))[0]
+
+	id, err := strconv.ParseUint(field, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
+
+// newContextID returns a random identifier used to tag a Context's log
+// lines, so every Make/Close/error line for that Context can be
+// correlated together.
+func newContextID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(buf)
+}